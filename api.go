@@ -1,18 +1,10 @@
 package pagsego
 
 import (
-	"bytes"
-	"code.google.com/p/go-charset/charset"
-	_ "code.google.com/p/go-charset/data"
-	"crypto/tls"
+	"context"
 	"encoding/xml"
-	"fmt"
-	"io"
 	"log"
-	"net"
-	"net/http"
 	"strconv"
-	"time"
 )
 
 const (
@@ -20,7 +12,6 @@ const (
 	ShippingSEDEX = 2
 	ShippingOther = 3
 	XMLHeader     = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`
-	CheckoutURL   = "https://ws.pagseguro.uol.com.br/v2/checkout"
 )
 
 type PaymentRequest struct {
@@ -38,7 +29,10 @@ type PaymentRequest struct {
 	MaxUses         string         `xml:"maxUses,omitempty"`  // from 0 to 999 (the amount of tries a user can do with the same reference ID)
 	MaxAge          string         `xml:"maxAge,omitempty"`   // time (in seconds) that the returned payment code is valid (30-999999999)
 	Metadata        []*Metadata    `xml:"metadata,omitempty"` // https://pagseguro.uol.com.br/v2/guia-de-integracao/api-de-pagamentos.html#v2-item-api-de-pagamentos-parametros-http
-	IsSandbox       bool           `xml:"-"`                  // o PagSeguro não tem um modo sandbox no momento (╯°□°）╯︵ ┻━┻
+	IsSandbox       bool           `xml:"-"`                  // when true, Submit hits the Sandbox Environment instead of Production; ignored after SetAuthorization, which uses App.IsSandbox
+
+	App           *App           `xml:"-"` // set by SetAuthorization; submits on behalf of Authorization's seller instead of Email/Token
+	Authorization *Authorization `xml:"-"` // set by SetAuthorization
 }
 
 type PaymentItem struct {
@@ -93,7 +87,7 @@ type Metadata struct {
 }
 
 type ErrorResponse struct {
-	Errors []XMLError `xml:"errors"`
+	Errors []XMLError `xml:"error"`
 }
 
 type XMLError struct {
@@ -110,10 +104,16 @@ type PaymentPreResponse struct {
 
 type PaymentPreSubmitResult struct {
 	CheckoutResponse *PaymentPreResponse
-	Error            *ErrorResponse
+	Error            *PagSeguroError
 	Success          bool
 }
 
+// toPriceAmountStr formats a price the way PagSeguro's XML API expects:
+// a fixed two decimal places, dot-separated (e.g. "10.50").
+func toPriceAmountStr(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
 func NewPaymentRequest(sellerToken, sellerEmail, referenceID, redirectURL, notificationURL string) *PaymentRequest {
 	req := &PaymentRequest{
 		Email:           sellerEmail,
@@ -236,91 +236,46 @@ func (r *Shipping) SetAddressCountry(country string) *Shipping {
 	return r
 }
 
-func (r *PaymentRequest) Submit() (result *PaymentPreSubmitResult) {
-	result = &PaymentPreSubmitResult{}
-
-	// Conectar com timeout caso o PagSeguro esteja morgando
-	functimeout := func(network, addr string) (net.Conn, error) {
-		return net.DialTimeout(network, addr, time.Duration(30*time.Second))
-	}
-
-	// create a custom http client that ignores https cert validity, so we don't have to install PagSeguro CAs
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		Dial:            functimeout,
-	}
-	client := &http.Client{Transport: tr}
-
-	// generate xml
-	xmlb, err := xml.Marshal(r)
-
-	if err != nil {
-		log.Println("^~PAGSEGO~^ XML MARSHAL ERROR: " + err.Error())
-		return
-	}
-
-	var clBuffer bytes.Buffer
-	clBuffer.WriteString(XMLHeader)
-	clBuffer.Write(xmlb)
-
-	checkoutURL := fmt.Sprintf("%s?email=%s&token=%s&charset=%s", CheckoutURL, r.Email, r.Token, "UTF-8")
-
-	// send the request (this goroutine is blocked until a response is received)
-	resp, err := client.Post(checkoutURL, "application/xml", &clBuffer)
-
-	if err != nil {
-		log.Println("^~PAGSEGO~^ client.Post ERROR: " + err.Error())
-		return
-	}
-
-	defer resp.Body.Close()
-	clBuffer.Truncate(0)
-
-	// io.Copy has a 32kB max buffer size, so no extra memory is consumed
-	io.Copy(&clBuffer, resp.Body)
-	respBytes := clBuffer.Bytes()
-	log.Println(string(respBytes))
-	var decoder *xml.Decoder
-
-	errors := &ErrorResponse{}
-
-	clBuffer.Truncate(0)
-	clBuffer.Write(respBytes)
-	decoder = xml.NewDecoder(&clBuffer)
-	decoder.CharsetReader = charset.NewReader
-	err = decoder.Decode(errors)
+// SetAuthorization makes r submit on behalf of authorization's seller,
+// authenticating with app's AppID/AppKey and the seller-specific
+// authorizationCode instead of r's own Email/Token. Use this when acting as
+// a platform under PagSeguro's Application authorization flow; see App.
+func (r *PaymentRequest) SetAuthorization(app *App, authorization *Authorization) *PaymentRequest {
+	r.App = app
+	r.Authorization = authorization
+	return r
+}
 
+// Submit sends the checkout request using a default Client built from the
+// PaymentRequest's own credentials, logging any error to the standard
+// logger. It is kept as a thin wrapper around Client.Submit for backward
+// compatibility; new code should build a Client (with WithLogger,
+// WithHTTPClient, etc.) and call Client.Submit or Client.SubmitContext.
+func (r *PaymentRequest) Submit() *PaymentPreSubmitResult {
+	result, err := r.defaultClient().Submit(r)
 	if err != nil {
-		// an error was not found!
-		//log.Println("^~PAGSEGO~^ Unmarshal(errors)  ERROR: " + err.Error())
-		//return
-	} else {
-		if errors.Errors != nil {
-			if len(errors.Errors) > 0 {
-				//log.Println("LOL ERRORS")
-				//log.Println(errors.Errors[0].Message)
-				result.Error = errors
-				result.Success = false
-				return
-			}
+		log.Println("^~PAGSEGO~^ " + err.Error())
+		result = &PaymentPreSubmitResult{Success: false}
+		if pagErr, ok := err.(*PagSeguroError); ok {
+			result.Error = pagErr
 		}
 	}
+	return result
+}
 
-	success := &PaymentPreResponse{}
-
-	clBuffer.Truncate(0)
-	clBuffer.Write(respBytes)
-	decoder = xml.NewDecoder(&clBuffer)
-	decoder.CharsetReader = charset.NewReader
-	err = decoder.Decode(success)
+// SubmitContext is Submit with a caller-supplied context, allowing the
+// request to be cancelled or bound to a deadline.
+func (r *PaymentRequest) SubmitContext(ctx context.Context) (*PaymentPreSubmitResult, error) {
+	return r.defaultClient().SubmitContext(ctx, r)
+}
 
-	if err != nil {
-		log.Println("^~PAGSEGO~^ Unmarshal(success)  ERROR: " + err.Error())
-		result.Success = false
-		return
+func (r *PaymentRequest) defaultClient() *Client {
+	if r.App != nil && r.Authorization != nil {
+		// The Client inherits its Sandbox setting from r.App, since an
+		// Authorization is only valid in the Environment it was granted in.
+		return NewClient("", "", WithAuthorization(r.App, r.Authorization))
 	}
-
-	result.CheckoutResponse = success
-	result.Success = true
-	return
+	c := NewClient(r.Email, r.Token)
+	c.IsSandbox = r.IsSandbox
+	return c
 }
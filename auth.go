@@ -0,0 +1,294 @@
+package pagsego
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// AuthorizationPermission is one of PagSeguro's documented permission codes
+// a platform can ask a seller to grant.
+// https://pagseguro.uol.com.br/v2/guia-de-integracao/autorizacoes.html
+type AuthorizationPermission string
+
+const (
+	PermissionCreateCheckouts                 AuthorizationPermission = "CREATE_CHECKOUTS"
+	PermissionReceiveTransactionNotifications AuthorizationPermission = "RECEIVE_TRANSACTION_NOTIFICATIONS"
+	PermissionConsultTransactions             AuthorizationPermission = "CONSULT_TRANSACTIONS"
+	PermissionDirectPayment                   AuthorizationPermission = "DIRECT_PAYMENT"
+)
+
+// App identifies a PagSeguro Application registered for the Authorizations
+// flow, letting a platform submit checkouts and fetch transactions on
+// behalf of many sellers instead of a single seller's Email+Token. Pair it
+// with an Authorization (via PaymentRequest.SetAuthorization or
+// WithAuthorization) to act as a specific seller.
+type App struct {
+	AppID  string
+	AppKey string
+
+	IsSandbox  bool // when true, the App's own calls hit the Sandbox Environment instead of Production
+	HTTPClient *http.Client
+	Logger     Logger
+
+	env *Environment // set by WithAppBaseURL; overrides IsSandbox when non-nil
+}
+
+// AppOption configures an App built by NewApp.
+type AppOption func(*App)
+
+// WithAppHTTPClient overrides the *http.Client the App uses for its own API
+// calls (RequestAuthorizationURL, ExchangeNotificationCode).
+func WithAppHTTPClient(hc *http.Client) AppOption {
+	return func(a *App) { a.HTTPClient = hc }
+}
+
+// WithAppLogger attaches a Logger that receives one line per outgoing request.
+func WithAppLogger(l Logger) AppOption {
+	return func(a *App) { a.Logger = l }
+}
+
+// WithAppSandbox makes the App target PagSeguro's Sandbox environment.
+func WithAppSandbox(sandbox bool) AppOption {
+	return func(a *App) { a.IsSandbox = sandbox }
+}
+
+// WithAppBaseURL points every Authorizations-flow call the App makes at
+// baseURL instead of the Production/Sandbox presets. Useful for testing
+// against an httptest.Server.
+func WithAppBaseURL(baseURL string) AppOption {
+	return func(a *App) {
+		a.env = &Environment{
+			AuthorizationRequestURL:      baseURL + "/v2/authorizations/request",
+			AuthorizationURL:             baseURL + "/v2/authorization/request.jhtml",
+			AuthorizationNotificationURL: baseURL + "/v2/authorizations/notifications/%s",
+		}
+	}
+}
+
+// NewApp creates an App that authenticates its own requests with the given
+// AppID/AppKey.
+func NewApp(appID, appKey string, opts ...AppOption) *App {
+	a := &App{
+		AppID:      appID,
+		AppKey:     appKey,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *App) environment() Environment {
+	if a.env != nil {
+		return *a.env
+	}
+	if a.IsSandbox {
+		return Sandbox
+	}
+	return Production
+}
+
+func (a *App) logf(format string, v ...interface{}) {
+	if a.Logger != nil {
+		a.Logger.Printf(format, v...)
+	}
+}
+
+// credentials returns the appId+appKey query-string parameters every
+// Authorizations-flow request signs with.
+func (a *App) credentials() url.Values {
+	v := url.Values{}
+	v.Set("appId", a.AppID)
+	v.Set("appKey", a.AppKey)
+	return v
+}
+
+// AuthorizationRequest describes the access a platform is asking a seller
+// to grant an App, posted to /v2/authorizations/request.
+type AuthorizationRequest struct {
+	XMLName         xml.Name                  `xml:"authorizationRequest"`
+	Reference       string                    `xml:"reference,omitempty"`
+	RedirectURL     string                    `xml:"redirectURL,omitempty"`
+	NotificationURL string                    `xml:"notificationURL,omitempty"`
+	Permissions     []AuthorizationPermission `xml:"permissions>permission>code"`
+}
+
+// RequestAuthorizationURL registers req with PagSeguro and returns the URL
+// the seller should be redirected to in order to grant (or deny) it. Once
+// the seller responds, PagSeguro redirects back to req.RedirectURL with a
+// notificationCode in the query string; exchange it for a permanent
+// Authorization with ExchangeNotificationCode.
+func (a *App) RequestAuthorizationURL(ctx context.Context, req *AuthorizationRequest) (string, error) {
+	xmlb, err := xml.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("pagsego: marshal authorization request: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(XMLHeader)
+	buf.Write(xmlb)
+
+	reqURL := fmt.Sprintf("%s?%s", a.environment().AuthorizationRequestURL, a.credentials().Encode())
+
+	httpReq, err := http.NewRequest("POST", reqURL, &buf)
+	if err != nil {
+		return "", err
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Header.Set("Content-Type", "application/xml")
+
+	a.logf("POST %s", reqURL)
+
+	resp, err := a.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("pagsego: request authorization: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var respBuf bytes.Buffer
+	io.Copy(&respBuf, resp.Body)
+	respBytes := respBuf.Bytes()
+
+	errResp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(errResp); err == nil && len(errResp.Errors) > 0 {
+		return "", errorFromResponse(errResp)
+	}
+
+	code := &struct {
+		XMLName xml.Name `xml:"authorizationRequest"`
+		Code    string   `xml:"code"`
+	}{}
+	decoder = xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(code); err != nil {
+		return "", fmt.Errorf("pagsego: decode authorization request response: %w", err)
+	}
+
+	return fmt.Sprintf("%s?code=%s", a.environment().AuthorizationURL, url.QueryEscape(code.Code)), nil
+}
+
+// Authorization is the permanent credential PagSeguro issues for one
+// seller once they grant an App access, analogous to an oauth2.Token.
+type Authorization struct {
+	Code        string // the authorizationCode
+	SellerEmail string
+}
+
+// ExchangeNotificationCode resolves the notificationCode PagSeguro appended
+// to the AuthorizationRequest's RedirectURL after the seller granted (or
+// denied) access into the permanent Authorization for that seller.
+func (a *App) ExchangeNotificationCode(ctx context.Context, notificationCode string) (*Authorization, error) {
+	reqURL := fmt.Sprintf(a.environment().AuthorizationNotificationURL, notificationCode)
+	reqURL = fmt.Sprintf("%s?%s", reqURL, a.credentials().Encode())
+
+	httpReq, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq = httpReq.WithContext(ctx)
+
+	a.logf("GET %s", reqURL)
+
+	resp, err := a.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("pagsego: exchange notification code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+	respBytes := buf.Bytes()
+
+	errResp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(errResp); err == nil && len(errResp.Errors) > 0 {
+		return nil, errorFromResponse(errResp)
+	}
+
+	info := &struct {
+		XMLName           xml.Name `xml:"authorizationInfo"`
+		AuthorizationCode string   `xml:"authorizationCode"`
+		ReceiverEmail     string   `xml:"receiverEmail"`
+	}{}
+	decoder = xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(info); err != nil {
+		return nil, fmt.Errorf("pagsego: decode authorization info: %w", err)
+	}
+
+	return &Authorization{Code: info.AuthorizationCode, SellerEmail: info.ReceiverEmail}, nil
+}
+
+// AuthorizationSource supplies the Authorization for a seller, modeled
+// after golang.org/x/oauth2's TokenSource so callers can plug in their own
+// persistence (database, cache, re-consent flow, etc.) instead of holding
+// authorizationCodes only in memory.
+type AuthorizationSource interface {
+	Authorization() (*Authorization, error)
+}
+
+// StaticAuthorization returns an AuthorizationSource that always returns
+// auth, analogous to oauth2.StaticTokenSource.
+func StaticAuthorization(auth *Authorization) AuthorizationSource {
+	return staticAuthorizationSource{auth}
+}
+
+type staticAuthorizationSource struct {
+	auth *Authorization
+}
+
+func (s staticAuthorizationSource) Authorization() (*Authorization, error) {
+	return s.auth, nil
+}
+
+// AuthorizationStore persists one Authorization per seller so a platform
+// can look one up again on a later request instead of re-running the
+// consent flow before every checkout.
+type AuthorizationStore interface {
+	Authorization(sellerEmail string) (*Authorization, error)
+	SaveAuthorization(sellerEmail string, auth *Authorization) error
+}
+
+// MemoryAuthorizationStore is an in-process AuthorizationStore backed by a
+// map. It is safe for concurrent use; useful for tests and single-instance
+// deployments that don't need durable storage.
+type MemoryAuthorizationStore struct {
+	mu   sync.Mutex
+	data map[string]*Authorization
+}
+
+// NewMemoryAuthorizationStore creates an empty MemoryAuthorizationStore.
+func NewMemoryAuthorizationStore() *MemoryAuthorizationStore {
+	return &MemoryAuthorizationStore{data: make(map[string]*Authorization)}
+}
+
+// Authorization returns the Authorization previously saved for
+// sellerEmail, or an error if none was saved.
+func (s *MemoryAuthorizationStore) Authorization(sellerEmail string) (*Authorization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	auth, ok := s.data[sellerEmail]
+	if !ok {
+		return nil, fmt.Errorf("pagsego: no authorization stored for %s", sellerEmail)
+	}
+	return auth, nil
+}
+
+// SaveAuthorization stores (or replaces) the Authorization for sellerEmail.
+func (s *MemoryAuthorizationStore) SaveAuthorization(sellerEmail string, auth *Authorization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[sellerEmail] = auth
+	return nil
+}
@@ -0,0 +1,77 @@
+package pagsego
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestAuthorizationURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<authorizationRequest><code>AUTHCODE123</code></authorizationRequest>`))
+	}))
+	defer ts.Close()
+
+	app := NewApp("app-id", "app-key", WithAppBaseURL(ts.URL))
+	authURL, err := app.RequestAuthorizationURL(context.Background(), &AuthorizationRequest{
+		RedirectURL: "https://example.com/callback",
+		Permissions: []AuthorizationPermission{PermissionCreateCheckouts},
+	})
+	if err != nil {
+		t.Fatalf("RequestAuthorizationURL returned error: %v", err)
+	}
+	if !strings.Contains(authURL, "code=AUTHCODE123") {
+		t.Errorf("expected authorization URL to carry the returned code, got %s", authURL)
+	}
+}
+
+func TestExchangeNotificationCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<authorizationInfo><authorizationCode>PERM123</authorizationCode><receiverEmail>seller@example.com</receiverEmail></authorizationInfo>`))
+	}))
+	defer ts.Close()
+
+	app := NewApp("app-id", "app-key", WithAppBaseURL(ts.URL))
+	auth, err := app.ExchangeNotificationCode(context.Background(), "NOTIF123")
+	if err != nil {
+		t.Fatalf("ExchangeNotificationCode returned error: %v", err)
+	}
+	if auth.Code != "PERM123" || auth.SellerEmail != "seller@example.com" {
+		t.Errorf("unexpected authorization: %+v", auth)
+	}
+}
+
+func TestMemoryAuthorizationStore(t *testing.T) {
+	store := NewMemoryAuthorizationStore()
+	if _, err := store.Authorization("seller@example.com"); err == nil {
+		t.Fatal("expected error for a seller with no stored authorization")
+	}
+
+	want := &Authorization{Code: "PERM123", SellerEmail: "seller@example.com"}
+	if err := store.SaveAuthorization("seller@example.com", want); err != nil {
+		t.Fatalf("SaveAuthorization returned error: %v", err)
+	}
+
+	got, err := store.Authorization("seller@example.com")
+	if err != nil {
+		t.Fatalf("Authorization returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestStaticAuthorization(t *testing.T) {
+	want := &Authorization{Code: "PERM123", SellerEmail: "seller@example.com"}
+	src := StaticAuthorization(want)
+
+	got, err := src.Authorization()
+	if err != nil {
+		t.Fatalf("Authorization returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,16 @@
+package pagsego
+
+import (
+	"io"
+
+	"golang.org/x/net/html/charset"
+)
+
+// xmlCharsetReader adapts golang.org/x/net/html/charset for use as an
+// encoding/xml Decoder's CharsetReader, so PagSeguro's non-UTF-8 XML
+// responses (ISO-8859-1, in practice) decode correctly. It replaces the
+// now-unreachable code.google.com/p/go-charset, which Google Code stopped
+// hosting years ago.
+func xmlCharsetReader(cs string, input io.Reader) (io.Reader, error) {
+	return charset.NewReaderLabel(cs, input)
+}
@@ -0,0 +1,198 @@
+package pagsego
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Logger is the minimal printf-style logging interface a Client accepts via
+// WithLogger. *log.Logger satisfies it; *slog.Logger does not, since it has
+// no Printf method.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Client holds the credentials used to authenticate against the PagSeguro
+// API, plus the HTTP transport and logging it should use. A Client
+// authenticates either as a single seller, with Email+Token, or as an
+// Application acting on behalf of many sellers, with App+Authorization (see
+// WithAuthorization) — exactly one of the two should be set.
+type Client struct {
+	Email      string
+	Token      string
+	IsSandbox  bool // when true, requests hit the Sandbox Environment instead of Production
+	HTTPClient *http.Client
+	Logger     Logger
+
+	App           *App           // set by WithAuthorization
+	Authorization *Authorization // set by WithAuthorization
+
+	env *Environment // set by WithBaseURL; overrides IsSandbox when non-nil
+}
+
+// ClientOption configures a Client built by NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used to talk to PagSeguro. By
+// default NewClient uses http.Client with a 30 second timeout.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) { c.HTTPClient = hc }
+}
+
+// WithLogger attaches a Logger that receives one line per outgoing request.
+func WithLogger(l Logger) ClientOption {
+	return func(c *Client) { c.Logger = l }
+}
+
+// WithTimeout overrides the Client's HTTP timeout. It must be applied after
+// WithHTTPClient, if both are given, since it mutates the current
+// HTTPClient's Timeout field.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) { c.HTTPClient.Timeout = d }
+}
+
+// WithAuthorization makes the Client act on behalf of the seller identified
+// by authorization, authenticating with app's AppID/AppKey and the
+// seller-specific authorizationCode instead of an Email/Token pair. See the
+// App type for how to obtain an Authorization.
+func WithAuthorization(app *App, authorization *Authorization) ClientOption {
+	return func(c *Client) {
+		c.App = app
+		c.Authorization = authorization
+		c.IsSandbox = app.IsSandbox
+	}
+}
+
+// WithBaseURL points every API call the Client makes at baseURL instead of
+// the Production/Sandbox presets. Useful for testing against an
+// httptest.Server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.env = &Environment{
+			CheckoutURL:     baseURL + "/v2/checkout",
+			NotificationURL: baseURL + "/v3/transactions/notifications/%s",
+			TransactionURL:  baseURL + "/v3/transactions/%s",
+			SessionURL:      baseURL + "/v2/sessions",
+			TransactionsURL: baseURL + "/v2/transactions",
+		}
+	}
+}
+
+// NewClient creates a Client that authenticates requests with the seller's
+// email and application token.
+func NewClient(sellerEmail, sellerToken string, opts ...ClientOption) *Client {
+	c := &Client{
+		Email:      sellerEmail,
+		Token:      sellerToken,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) environment() Environment {
+	if c.env != nil {
+		return *c.env
+	}
+	if c.IsSandbox {
+		return Sandbox
+	}
+	return Production
+}
+
+func (c *Client) httpClient() *http.Client {
+	return c.HTTPClient
+}
+
+// credentials returns the query-string parameters PagSeguro requires to
+// identify the caller: appId+appKey+authorizationCode when the Client was
+// built with WithAuthorization, or the classic email+token otherwise.
+func (c *Client) credentials() url.Values {
+	v := url.Values{}
+	if c.App != nil && c.Authorization != nil {
+		v.Set("appId", c.App.AppID)
+		v.Set("appKey", c.App.AppKey)
+		v.Set("authorizationCode", c.Authorization.Code)
+		return v
+	}
+	v.Set("email", c.Email)
+	v.Set("token", c.Token)
+	return v
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+// Submit sends a checkout PaymentRequest and returns the decoded response.
+func (c *Client) Submit(r *PaymentRequest) (*PaymentPreSubmitResult, error) {
+	return c.SubmitContext(context.Background(), r)
+}
+
+// SubmitContext is Submit with a caller-supplied context, allowing the
+// request to be cancelled or bound to a deadline.
+func (c *Client) SubmitContext(ctx context.Context, r *PaymentRequest) (*PaymentPreSubmitResult, error) {
+	result := &PaymentPreSubmitResult{}
+
+	xmlb, err := xml.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("pagsego: marshal checkout request: %w", err)
+	}
+
+	var clBuffer bytes.Buffer
+	clBuffer.WriteString(XMLHeader)
+	clBuffer.Write(xmlb)
+
+	q := c.credentials()
+	q.Set("charset", "UTF-8")
+	checkoutURL := fmt.Sprintf("%s?%s", c.environment().CheckoutURL, q.Encode())
+
+	req, err := http.NewRequest("POST", checkoutURL, &clBuffer)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/xml")
+
+	c.logf("POST %s", checkoutURL)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pagsego: submit checkout: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+	respBytes := buf.Bytes()
+
+	c.logf("response: %s", string(respBytes))
+
+	errResp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(errResp); err == nil && len(errResp.Errors) > 0 {
+		return nil, errorFromResponse(errResp)
+	}
+
+	success := &PaymentPreResponse{}
+	decoder = xml.NewDecoder(bytes.NewReader(respBytes))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(success); err != nil {
+		return nil, fmt.Errorf("pagsego: decode checkout response: %w", err)
+	}
+
+	result.CheckoutResponse = success
+	result.Success = true
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package pagsego
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, format)
+}
+
+func TestClientSubmitContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<checkout><code>ABC123</code></checkout>`))
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+	c := NewClient("seller@example.com", "token", WithBaseURL(ts.URL), WithLogger(logger))
+
+	req := NewPaymentRequest("token", "seller@example.com", "ref", "", "")
+	req.AddItem("0001", "Widget", 10, 1)
+
+	result, err := c.SubmitContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SubmitContext returned error: %v", err)
+	}
+	if !result.Success || result.CheckoutResponse == nil || result.CheckoutResponse.Code != "ABC123" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(logger.lines) == 0 {
+		t.Error("expected logger to receive at least one line")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	c := NewClient("seller@example.com", "token", WithTimeout(5*time.Second))
+	if c.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("expected timeout to be overridden, got %v", c.HTTPClient.Timeout)
+	}
+}
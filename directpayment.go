@@ -0,0 +1,340 @@
+package pagsego
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DirectPaymentMethod selects how the buyer pays in the transparent
+// (checkout transparente) flow.
+type DirectPaymentMethod string
+
+const (
+	DirectPaymentCreditCard  DirectPaymentMethod = "creditCard"
+	DirectPaymentBoleto      DirectPaymentMethod = "boleto"
+	DirectPaymentOnlineDebit DirectPaymentMethod = "eft"
+)
+
+// BankName identifies the bank used for online debit (eft) payments.
+type BankName string
+
+const (
+	BankBancoDoBrasil BankName = "bancoDoBrasil"
+	BankBradesco      BankName = "bradesco"
+	BankItau          BankName = "itau"
+	BankBanrisul      BankName = "banrisul"
+	BankHSBC          BankName = "hsbc"
+)
+
+// CreditCardHolder is the cardholder data PagSeguro requires alongside the
+// front-end-generated card token, sent as the creditCardHolder* form fields.
+type CreditCardHolder struct {
+	Name     string
+	CPF      string
+	BornDate string // dd/MM/yyyy, sent as creditCardHolderBirthDate
+	AreaCode string
+	Phone    string
+}
+
+// Installment describes how a credit card purchase is split.
+type Installment struct {
+	Quantity int
+	Value    string
+}
+
+// CreditCard carries the token produced by PagSeguro's front-end JS SDK
+// together with the cardholder and installment data.
+type CreditCard struct {
+	Token       string
+	Holder      *CreditCardHolder
+	Installment *Installment
+}
+
+// Bank selects the financial institution for an online debit payment.
+type Bank struct {
+	Name BankName
+}
+
+// DirectPaymentRequest mirrors PaymentRequest but targets PagSeguro's
+// transparent checkout (/v2/transactions): paymentMode is always "default"
+// and paymentMethod picks credit card, boleto or online debit. Unlike
+// PaymentRequest, this is submitted as a flat application/x-www-form-urlencoded
+// body (see values), not nested XML, matching the direct-payment API's own
+// field names.
+type DirectPaymentRequest struct {
+	Mode            string
+	Method          DirectPaymentMethod
+	Currency        string
+	Items           []*PaymentItem
+	ReferenceID     string
+	Sender          *Buyer
+	SenderHash      string // required; see SetSenderHash
+	Shipping        *Shipping
+	ExtraAmount     string
+	NotificationURL string
+	CreditCard      *CreditCard
+	Bank            *Bank
+}
+
+// NewDirectPaymentRequest creates a transparent checkout transaction for the
+// given payment method.
+func NewDirectPaymentRequest(referenceID string, method DirectPaymentMethod) *DirectPaymentRequest {
+	return &DirectPaymentRequest{
+		Mode:        "default",
+		Method:      method,
+		Currency:    "BRL",
+		ReferenceID: referenceID,
+	}
+}
+
+func (r *DirectPaymentRequest) AddItem(id string, description string, amount float64, quantity int) *PaymentItem {
+	item := &PaymentItem{
+		Id:          id,
+		Description: description,
+		PriceAmount: toPriceAmountStr(amount),
+		Quantity:    strconv.Itoa(quantity),
+	}
+	if r.Items == nil {
+		r.Items = make([]*PaymentItem, 0)
+	}
+	r.Items = append(r.Items, item)
+
+	return item
+}
+
+func (r *DirectPaymentRequest) SetBuyer(name, email string) *Buyer {
+	buyer := &Buyer{
+		Name:  name,
+		Email: email,
+	}
+	r.Sender = buyer
+	return buyer
+}
+
+// SetSenderHash attaches the anti-fraud sender hash PagSeguroDirectPayment's
+// front-end JS SDK generates for the buyer's browsing session (see
+// PagSeguroDirectPayment.onSenderHashReady). PagSeguro rejects transparent
+// checkout transactions submitted without one.
+func (r *DirectPaymentRequest) SetSenderHash(hash string) *DirectPaymentRequest {
+	r.SenderHash = hash
+	return r
+}
+
+// SetCreditCard attaches the front-end-generated token and installment data
+// required by the creditCard payment method. Cardholder data is added
+// separately with CreditCard.SetHolder.
+func (r *DirectPaymentRequest) SetCreditCard(token string, installments int, installmentValue float64) *CreditCard {
+	cc := &CreditCard{
+		Token: token,
+		Installment: &Installment{
+			Quantity: installments,
+			Value:    toPriceAmountStr(installmentValue),
+		},
+	}
+	r.CreditCard = cc
+	return cc
+}
+
+func (cc *CreditCard) SetHolder(name, cpf, bornDate string) *CreditCardHolder {
+	holder := &CreditCardHolder{
+		Name:     name,
+		CPF:      cpf,
+		BornDate: bornDate,
+	}
+	cc.Holder = holder
+	return holder
+}
+
+func (h *CreditCardHolder) SetPhone(areaCode, phone string) *CreditCardHolder {
+	h.AreaCode = areaCode
+	h.Phone = phone
+	return h
+}
+
+// SetBank attaches the chosen bank for an online debit (eft) payment.
+func (r *DirectPaymentRequest) SetBank(name BankName) *Bank {
+	bank := &Bank{Name: name}
+	r.Bank = bank
+	return bank
+}
+
+// values encodes r into the flat form fields PagSeguro's /v2/transactions
+// direct-payment endpoint expects: itemId{n}/itemDescription{n}/itemAmount{n}/
+// itemQuantity{n} per item (1-indexed), senderX for the buyer,
+// creditCardHolderX for the card, etc. This is a different wire shape than
+// PaymentRequest's nested XML, even though the two share Go types like Buyer
+// and Shipping.
+// Reference: https://dev.pagseguro.uol.com.br/reference/pagamento-direto
+func (r *DirectPaymentRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("paymentMode", r.Mode)
+	v.Set("paymentMethod", string(r.Method))
+	v.Set("currency", r.Currency)
+	if r.ReferenceID != "" {
+		v.Set("reference", r.ReferenceID)
+	}
+
+	for i, item := range r.Items {
+		n := strconv.Itoa(i + 1)
+		v.Set("itemId"+n, item.Id)
+		v.Set("itemDescription"+n, item.Description)
+		v.Set("itemAmount"+n, item.PriceAmount)
+		v.Set("itemQuantity"+n, item.Quantity)
+		if item.ShippingCost != "" {
+			v.Set("itemShippingCost"+n, item.ShippingCost)
+		}
+		if item.Weight != "" {
+			v.Set("itemWeight"+n, item.Weight)
+		}
+	}
+
+	if r.Sender != nil {
+		v.Set("senderName", r.Sender.Name)
+		v.Set("senderEmail", r.Sender.Email)
+		if r.Sender.BornDate != "" {
+			v.Set("senderBirthDate", r.Sender.BornDate)
+		}
+		for _, doc := range r.Sender.Documents {
+			if doc.Type == "CPF" {
+				v.Set("senderCPF", doc.Value)
+			}
+		}
+		if r.Sender.Phone != nil {
+			v.Set("senderAreaCode", r.Sender.Phone.AreaCode)
+			v.Set("senderPhone", r.Sender.Phone.PhoneNumber)
+		}
+	}
+	if r.SenderHash != "" {
+		v.Set("senderHash", r.SenderHash)
+	}
+
+	if r.Shipping != nil {
+		v.Set("shippingType", r.Shipping.Type)
+		if r.Shipping.Cost != "" {
+			v.Set("shippingCost", r.Shipping.Cost)
+		}
+		if addr := r.Shipping.Address; addr != nil {
+			v.Set("shippingAddressCountry", addr.Country)
+			v.Set("shippingAddressState", addr.State)
+			v.Set("shippingAddressCity", addr.City)
+			v.Set("shippingAddressPostalCode", addr.PostalCode)
+			v.Set("shippingAddressDistrict", addr.District)
+			v.Set("shippingAddressStreet", addr.Street)
+			v.Set("shippingAddressNumber", addr.Number)
+			v.Set("shippingAddressComplement", addr.Complement)
+		}
+	}
+
+	if r.ExtraAmount != "" {
+		v.Set("extraAmount", r.ExtraAmount)
+	}
+	if r.NotificationURL != "" {
+		v.Set("notificationURL", r.NotificationURL)
+	}
+
+	if r.CreditCard != nil {
+		v.Set("creditCardToken", r.CreditCard.Token)
+		if inst := r.CreditCard.Installment; inst != nil {
+			v.Set("installmentQuantity", strconv.Itoa(inst.Quantity))
+			v.Set("installmentValue", inst.Value)
+		}
+		if h := r.CreditCard.Holder; h != nil {
+			v.Set("creditCardHolderName", h.Name)
+			v.Set("creditCardHolderCPF", h.CPF)
+			v.Set("creditCardHolderBirthDate", h.BornDate)
+			v.Set("creditCardHolderAreaCode", h.AreaCode)
+			v.Set("creditCardHolderPhone", h.Phone)
+		}
+	}
+
+	if r.Bank != nil {
+		v.Set("bankName", string(r.Bank.Name))
+	}
+
+	return v
+}
+
+// CreateSession fetches a one-time session ID from /v2/sessions, required by
+// PagSeguro's front-end JS SDK before it can tokenize a credit card.
+func (c *Client) CreateSession() (string, error) {
+	return c.CreateSessionContext(context.Background())
+}
+
+// CreateSessionContext is CreateSession with a caller-supplied context.
+func (c *Client) CreateSessionContext(ctx context.Context) (string, error) {
+	reqURL := fmt.Sprintf("%s?%s", c.environment().SessionURL, c.credentials().Encode())
+
+	req, err := http.NewRequest("POST", reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	c.logf("POST %s", reqURL)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("pagsego: create session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+
+	errResp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(errResp); err == nil && len(errResp.Errors) > 0 {
+		return "", errorFromResponse(errResp)
+	}
+
+	session := &struct {
+		XMLName xml.Name `xml:"session"`
+		Id      string   `xml:"id"`
+	}{}
+	decoder = xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(session); err != nil {
+		return "", err
+	}
+	return session.Id, nil
+}
+
+// SubmitDirectPayment posts a transparent checkout transaction to
+// /v2/transactions and decodes the resulting Transaction.
+func (c *Client) SubmitDirectPayment(r *DirectPaymentRequest) (*Transaction, error) {
+	return c.SubmitDirectPaymentContext(context.Background(), r)
+}
+
+// SubmitDirectPaymentContext is SubmitDirectPayment with a caller-supplied context.
+func (c *Client) SubmitDirectPaymentContext(ctx context.Context, r *DirectPaymentRequest) (*Transaction, error) {
+	reqURL := fmt.Sprintf("%s?%s", c.environment().TransactionsURL, c.credentials().Encode())
+
+	req, err := http.NewRequest("POST", reqURL, strings.NewReader(r.values().Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	c.logf("POST %s", reqURL)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pagsego: submit direct payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+
+	return decodeTransactionResponse(buf.Bytes())
+}
@@ -0,0 +1,154 @@
+package pagsego
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClientCreateSession(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<session><id>SESS123</id></session>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("seller@example.com", "token", WithBaseURL(ts.URL))
+
+	id, err := c.CreateSession()
+	if err != nil {
+		t.Fatalf("CreateSession returned error: %v", err)
+	}
+	if id != "SESS123" {
+		t.Errorf("expected session id %q, got %q", "SESS123", id)
+	}
+}
+
+func TestClientCreateSessionError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<errors><error><code>11014</code><message>token inválido</message></error></errors>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("seller@example.com", "token", WithBaseURL(ts.URL))
+
+	if _, err := c.CreateSession(); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestClientSubmitDirectPaymentCreditCard(t *testing.T) {
+	var gotContentType string
+	var gotBody url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		raw, _ := io.ReadAll(r.Body)
+		gotBody, _ = url.ParseQuery(string(raw))
+		w.Write([]byte(`<transaction><code>TX123</code><status>3</status></transaction>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("seller@example.com", "token", WithBaseURL(ts.URL))
+
+	req := NewDirectPaymentRequest("ref0001", DirectPaymentCreditCard)
+	item := req.AddItem("0001", "Widget", 10, 1)
+	item.SetShippingCost(5)
+	item.SetWeight(300)
+	buyer := req.SetBuyer("Jane Buyer", "buyer@example.com")
+	buyer.BornDate = "02/02/1985"
+	req.SetSenderHash("sender-hash-123")
+	cc := req.SetCreditCard("card-token", 1, 10)
+	holder := cc.SetHolder("Jane Buyer", "12345678909", "01/01/1990")
+	holder.SetPhone("11", "999999999")
+
+	tx, err := c.SubmitDirectPayment(req)
+	if err != nil {
+		t.Fatalf("SubmitDirectPayment returned error: %v", err)
+	}
+	if tx.Code != "TX123" || tx.Status != StatusPaid {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("expected form-urlencoded content type, got %q", gotContentType)
+	}
+	wantFields := map[string]string{
+		"paymentMode":               "default",
+		"paymentMethod":             "creditCard",
+		"itemId1":                   "0001",
+		"itemAmount1":               "10.00",
+		"itemQuantity1":             "1",
+		"itemShippingCost1":         "5.00",
+		"itemWeight1":               "300",
+		"senderName":                "Jane Buyer",
+		"senderEmail":               "buyer@example.com",
+		"senderBirthDate":           "02/02/1985",
+		"senderHash":                "sender-hash-123",
+		"creditCardToken":           "card-token",
+		"installmentQuantity":       "1",
+		"installmentValue":          "10.00",
+		"creditCardHolderName":      "Jane Buyer",
+		"creditCardHolderCPF":       "12345678909",
+		"creditCardHolderBirthDate": "01/01/1990",
+		"creditCardHolderAreaCode":  "11",
+		"creditCardHolderPhone":     "999999999",
+	}
+	for field, want := range wantFields {
+		if got := gotBody.Get(field); got != want {
+			t.Errorf("field %s: expected %q, got %q", field, want, got)
+		}
+	}
+}
+
+func TestClientSubmitDirectPaymentOnlineDebit(t *testing.T) {
+	var gotBody url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		gotBody, _ = url.ParseQuery(string(raw))
+		w.Write([]byte(`<transaction><code>TX456</code><status>1</status></transaction>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("seller@example.com", "token", WithBaseURL(ts.URL))
+
+	req := NewDirectPaymentRequest("ref0002", DirectPaymentOnlineDebit)
+	req.AddItem("0001", "Widget", 10, 1)
+	req.SetBuyer("Jane Buyer", "buyer@example.com")
+	req.SetSenderHash("sender-hash-456")
+	req.Shipping = &Shipping{Type: "1", Cost: "8.00"}
+	req.SetBank(BankBancoDoBrasil)
+
+	tx, err := c.SubmitDirectPayment(req)
+	if err != nil {
+		t.Fatalf("SubmitDirectPayment returned error: %v", err)
+	}
+	if tx.Code != "TX456" || tx.Status != StatusWaiting {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	if got := gotBody.Get("paymentMethod"); got != "eft" {
+		t.Errorf("expected paymentMethod %q, got %q", "eft", got)
+	}
+	if got := gotBody.Get("bankName"); got != "bancoDoBrasil" {
+		t.Errorf("expected bankName %q, got %q", "bancoDoBrasil", got)
+	}
+	if got := gotBody.Get("shippingCost"); got != "8.00" {
+		t.Errorf("expected shippingCost %q, got %q", "8.00", got)
+	}
+}
+
+func TestDirectPaymentRequestAddItem(t *testing.T) {
+	req := NewDirectPaymentRequest("ref0003", DirectPaymentBoleto)
+	item := req.AddItem("0001", "Widget", 19.9, 2)
+
+	if item.PriceAmount != "19.90" {
+		t.Errorf("expected PriceAmount %q, got %q", "19.90", item.PriceAmount)
+	}
+	if item.Quantity != "2" {
+		t.Errorf("expected Quantity %q, got %q", "2", item.Quantity)
+	}
+	if len(req.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(req.Items))
+	}
+}
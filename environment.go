@@ -0,0 +1,54 @@
+package pagsego
+
+// Environment groups the base URLs for a PagSeguro API deployment. PagSeguro
+// exposes both its production endpoints and a sandbox (test) deployment on
+// separate hosts; Production and Sandbox below are the two supported presets.
+type Environment struct {
+	CheckoutURL     string
+	NotificationURL string // format string, expects the notificationCode
+	TransactionURL  string // format string, expects the transaction code
+	SessionURL      string
+	TransactionsURL string // POST target for the transparent checkout API
+
+	AuthorizationRequestURL      string // POST target that mints the code used to build AuthorizationURL
+	AuthorizationURL             string // user-facing host; the seller is redirected here with ?code=...
+	AuthorizationNotificationURL string // format string, expects the notificationCode, resolves to an authorizationCode
+}
+
+var (
+	// Production is PagSeguro's live environment.
+	Production = Environment{
+		CheckoutURL:     "https://ws.pagseguro.uol.com.br/v2/checkout",
+		NotificationURL: "https://ws.pagseguro.uol.com.br/v3/transactions/notifications/%s",
+		TransactionURL:  "https://ws.pagseguro.uol.com.br/v3/transactions/%s",
+		SessionURL:      "https://ws.pagseguro.uol.com.br/v2/sessions",
+		TransactionsURL: "https://ws.pagseguro.uol.com.br/v2/transactions",
+
+		AuthorizationRequestURL:      "https://ws.pagseguro.uol.com.br/v2/authorizations/request",
+		AuthorizationURL:             "https://pagseguro.uol.com.br/v2/authorization/request.jhtml",
+		AuthorizationNotificationURL: "https://ws.pagseguro.uol.com.br/v2/authorizations/notifications/%s",
+	}
+
+	// Sandbox is PagSeguro's test environment. Transactions created here
+	// never move real money and require a sandbox seller account.
+	Sandbox = Environment{
+		CheckoutURL:     "https://ws.sandbox.pagseguro.uol.com.br/v2/checkout",
+		NotificationURL: "https://ws.sandbox.pagseguro.uol.com.br/v3/transactions/notifications/%s",
+		TransactionURL:  "https://ws.sandbox.pagseguro.uol.com.br/v3/transactions/%s",
+		SessionURL:      "https://ws.sandbox.pagseguro.uol.com.br/v2/sessions",
+		TransactionsURL: "https://ws.sandbox.pagseguro.uol.com.br/v2/transactions",
+
+		AuthorizationRequestURL:      "https://ws.sandbox.pagseguro.uol.com.br/v2/authorizations/request",
+		AuthorizationURL:             "https://sandbox.pagseguro.uol.com.br/v2/authorization/request.jhtml",
+		AuthorizationNotificationURL: "https://ws.sandbox.pagseguro.uol.com.br/v2/authorizations/notifications/%s",
+	}
+)
+
+// environment returns the Environment a PaymentRequest should hit, based on
+// its IsSandbox flag.
+func (r *PaymentRequest) environment() Environment {
+	if r.IsSandbox {
+		return Sandbox
+	}
+	return Production
+}
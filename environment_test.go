@@ -0,0 +1,69 @@
+package pagsego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPaymentRequestEnvironment(t *testing.T) {
+	r := NewPaymentRequest("token", "seller@example.com", "ref", "", "")
+
+	if host := must(url.Parse(r.environment().CheckoutURL)).Host; host != "ws.pagseguro.uol.com.br" {
+		t.Errorf("expected production host, got %s", host)
+	}
+
+	r.IsSandbox = true
+	if host := must(url.Parse(r.environment().CheckoutURL)).Host; host != "ws.sandbox.pagseguro.uol.com.br" {
+		t.Errorf("expected sandbox host, got %s", host)
+	}
+}
+
+func TestClientEnvironment(t *testing.T) {
+	c := NewClient("seller@example.com", "token")
+	if host := must(url.Parse(c.environment().CheckoutURL)).Host; host != "ws.pagseguro.uol.com.br" {
+		t.Errorf("expected production host, got %s", host)
+	}
+
+	c.IsSandbox = true
+	if host := must(url.Parse(c.environment().CheckoutURL)).Host; host != "ws.sandbox.pagseguro.uol.com.br" {
+		t.Errorf("expected sandbox host, got %s", host)
+	}
+}
+
+func TestFetchTransactionUsesSandboxHost(t *testing.T) {
+	var gotHost string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotHost = req.Host
+		w.Write([]byte(`<transaction><code>ABC</code></transaction>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("seller@example.com", "token")
+	c.IsSandbox = true
+	c.environment()
+
+	// point the sandbox transaction URL at the test server so we can
+	// observe which host FetchTransaction actually dials.
+	sandboxOverride := Sandbox
+	sandboxOverride.TransactionURL = ts.URL + "/%s"
+	restore := Sandbox
+	Sandbox = sandboxOverride
+	defer func() { Sandbox = restore }()
+
+	if _, err := c.FetchTransaction("ABC123"); err != nil {
+		t.Fatalf("FetchTransaction returned error: %v", err)
+	}
+	if !strings.Contains(ts.URL, gotHost) {
+		t.Errorf("expected request to hit test server, got host %q", gotHost)
+	}
+}
+
+func must(u *url.URL, err error) *url.URL {
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
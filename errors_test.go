@@ -0,0 +1,63 @@
+package pagsego
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestPagSeguroErrorIs(t *testing.T) {
+	resp := &ErrorResponse{Errors: []XMLError{{Code: 11014, Message: "token inválido"}}}
+	err := errorFromResponse(resp)
+
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected errors.Is(err, ErrInvalidToken) to be true, err: %v", err)
+	}
+	if errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("expected errors.Is(err, ErrInvalidEmail) to be false, err: %v", err)
+	}
+
+	var pagErr *PagSeguroError
+	if !errors.As(err, &pagErr) {
+		t.Fatalf("expected errors.As to recover a *PagSeguroError")
+	}
+	if pagErr.Code() != 11014 {
+		t.Errorf("expected Code() 11014, got %d", pagErr.Code())
+	}
+}
+
+func TestPagSeguroErrorIsRetryable(t *testing.T) {
+	if !ErrServiceUnavailable.IsRetryable() {
+		t.Error("expected ErrServiceUnavailable to be retryable")
+	}
+	if ErrInvalidToken.IsRetryable() {
+		t.Error("expected ErrInvalidToken to not be retryable")
+	}
+}
+
+func TestErrorFromResponseNoErrors(t *testing.T) {
+	if err := errorFromResponse(&ErrorResponse{}); err != nil {
+		t.Errorf("expected nil error for an empty ErrorResponse, got %v", err)
+	}
+}
+
+// TestErrorResponseDecode guards against the <error> tag regressing back to
+// <errors>: ErrorResponse has no XMLName, so decoding treats <errors> as the
+// implicit root and Errors must match its direct <error> children, not repeat
+// the root's own element name.
+func TestErrorResponseDecode(t *testing.T) {
+	body := []byte(`<errors><error><code>11013</code><message>email do comprador inválido</message></error></errors>`)
+
+	resp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d: %+v", len(resp.Errors), resp.Errors)
+	}
+	if !errors.Is(errorFromResponse(resp), ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", errorFromResponse(resp))
+	}
+}
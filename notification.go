@@ -0,0 +1,138 @@
+package pagsego
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TransactionStatus represents the lifecycle state of a PagSeguro transaction,
+// as reported by the notifications/transactions API.
+type TransactionStatus int
+
+const (
+	StatusWaiting    TransactionStatus = 1 // Aguardando pagamento
+	StatusInAnalysis TransactionStatus = 2 // Em análise
+	StatusPaid       TransactionStatus = 3 // Paga
+	StatusAvailable  TransactionStatus = 4 // Disponível
+	StatusInDispute  TransactionStatus = 5 // Em disputa
+	StatusRefunded   TransactionStatus = 6 // Devolvida
+	StatusCancelled  TransactionStatus = 7 // Cancelada
+)
+
+// PaymentMethod describes how the buyer paid (credit card, boleto, etc).
+type PaymentMethod struct {
+	Type int `xml:"type"`
+	Code int `xml:"code"`
+}
+
+// TransactionItem is a line item as returned inside a Transaction.
+type TransactionItem struct {
+	Id          string `xml:"id"`
+	Description string `xml:"description"`
+	Quantity    string `xml:"quantity"`
+	Amount      string `xml:"amount"`
+}
+
+// Transaction is the decoded response of the notifications and transactions
+// endpoints (https://ws.pagseguro.uol.com.br/v3/transactions/...).
+type Transaction struct {
+	XMLName          xml.Name          `xml:"transaction"`
+	Date             string            `xml:"date"`
+	LastEventDate    string            `xml:"lastEventDate"`
+	Code             string            `xml:"code"`
+	Reference        string            `xml:"reference"`
+	Type             int               `xml:"type"`
+	Status           TransactionStatus `xml:"status"`
+	PaymentMethod    PaymentMethod     `xml:"paymentMethod"`
+	GrossAmount      string            `xml:"grossAmount"`
+	DiscountAmount   string            `xml:"discountAmount"`
+	FeeAmount        string            `xml:"feeAmount,omitempty"`
+	NetAmount        string            `xml:"netAmount"`
+	ExtraAmount      string            `xml:"extraAmount"`
+	InstallmentCount int               `xml:"installmentCount,omitempty"`
+	ItemCount        int               `xml:"itemCount"`
+	Items            []TransactionItem `xml:"items>item"`
+	Sender           *Buyer            `xml:"sender,omitempty"`
+	Shipping         *Shipping         `xml:"shipping,omitempty"`
+}
+
+func (c *Client) fetchTransaction(ctx context.Context, url string) (*Transaction, error) {
+	reqURL := fmt.Sprintf("%s?%s", url, c.credentials().Encode())
+
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	c.logf("GET %s", reqURL)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pagsego: fetch transaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+
+	return decodeTransactionResponse(buf.Bytes())
+}
+
+// decodeTransactionResponse decodes a PagSeguro transaction response body,
+// returning a *PagSeguroError when the API reports one.
+func decodeTransactionResponse(body []byte) (*Transaction, error) {
+	errResp := &ErrorResponse{}
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(errResp); err == nil && len(errResp.Errors) > 0 {
+		return nil, errorFromResponse(errResp)
+	}
+
+	tx := &Transaction{}
+	decoder = xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = xmlCharsetReader
+	if err := decoder.Decode(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// FetchNotification resolves a notificationCode (received on the IPN webhook)
+// into the full Transaction it refers to.
+func (c *Client) FetchNotification(notificationCode string) (*Transaction, error) {
+	return c.FetchNotificationContext(context.Background(), notificationCode)
+}
+
+// FetchNotificationContext is FetchNotification with a caller-supplied context.
+func (c *Client) FetchNotificationContext(ctx context.Context, notificationCode string) (*Transaction, error) {
+	return c.fetchTransaction(ctx, fmt.Sprintf(c.environment().NotificationURL, notificationCode))
+}
+
+// FetchTransaction looks up a transaction directly by its transaction code.
+func (c *Client) FetchTransaction(txCode string) (*Transaction, error) {
+	return c.FetchTransactionContext(context.Background(), txCode)
+}
+
+// FetchTransactionContext is FetchTransaction with a caller-supplied context.
+func (c *Client) FetchTransactionContext(ctx context.Context, txCode string) (*Transaction, error) {
+	return c.fetchTransaction(ctx, fmt.Sprintf(c.environment().TransactionURL, txCode))
+}
+
+// NewNotificationHandler returns an http.Handler that decodes the
+// form-encoded webhook PagSeguro posts to the merchant's NotificationURL
+// (notificationCode, notificationType) and invokes fn with the decoded
+// values.
+func NewNotificationHandler(fn func(notificationCode, notificationType string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fn(r.FormValue("notificationCode"), r.FormValue("notificationType"))
+	})
+}
@@ -0,0 +1,73 @@
+package pagsego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewNotificationHandler(t *testing.T) {
+	var gotCode, gotType string
+	handler := NewNotificationHandler(func(notificationCode, notificationType string) {
+		gotCode = notificationCode
+		gotType = notificationType
+	})
+
+	form := url.Values{}
+	form.Set("notificationCode", "NOTIF123")
+	form.Set("notificationType", "transaction")
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if gotCode != "NOTIF123" {
+		t.Errorf("expected notificationCode %q, got %q", "NOTIF123", gotCode)
+	}
+	if gotType != "transaction" {
+		t.Errorf("expected notificationType %q, got %q", "transaction", gotType)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestNewNotificationHandlerInvalidForm(t *testing.T) {
+	handler := NewNotificationHandler(func(notificationCode, notificationType string) {
+		t.Fatal("fn should not be called for a malformed request")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader("%"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestDecodeTransactionResponse(t *testing.T) {
+	body := []byte(`<transaction><code>TX123</code><status>3</status></transaction>`)
+	tx, err := decodeTransactionResponse(body)
+	if err != nil {
+		t.Fatalf("decodeTransactionResponse returned error: %v", err)
+	}
+	if tx.Code != "TX123" || tx.Status != StatusPaid {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+}
+
+func TestDecodeTransactionResponseError(t *testing.T) {
+	body := []byte(`<errors><error><code>11013</code><message>email do comprador inválido</message></error></errors>`)
+	_, err := decodeTransactionResponse(body)
+	if !errors.Is(err, ErrInvalidEmail) {
+		t.Fatalf("expected ErrInvalidEmail, got %v", err)
+	}
+}